@@ -0,0 +1,64 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"stl-parser/internal/stl"
+
+	"github.com/spf13/cobra"
+)
+
+var convertFormat string
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "This command converts a STL file between ASCII and binary format.",
+	Long:  `This command converts a STL file between ASCII and binary format.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := parseFormat(convertFormat)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+
+		src, err := os.Open(args[0])
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		defer src.Close()
+
+		dst, err := os.Create(args[1])
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		defer dst.Close()
+
+		if err := stl.Convert(src, dst, format); err != nil {
+			fmt.Println(err.Error())
+		}
+	},
+}
+
+func parseFormat(s string) (stl.Format, error) {
+	switch s {
+	case "ascii":
+		return stl.FormatASCII, nil
+	case "binary":
+		return stl.FormatBinary, nil
+	default:
+		return 0, fmt.Errorf("unknown format %q: want \"ascii\" or \"binary\"", s)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	// Here you will define your flags and configuration settings.
+	convertCmd.Flags().StringVar(&convertFormat, "format", "ascii", "output format: ascii or binary")
+}
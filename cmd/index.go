@@ -0,0 +1,76 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"stl-parser/internal/stl"
+
+	"github.com/spf13/cobra"
+)
+
+var indexEpsilon float32
+var indexOBJPath string
+
+// indexCmd represents the index command
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "This command builds a deduplicated, indexed mesh from a STL file.",
+	Long:  `This command builds a deduplicated, indexed mesh from a STL file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		defer f.Close()
+
+		solid, err := stl.Decode(f)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+
+		mesh := stl.BuildIndexedSolid(solid, indexEpsilon)
+
+		fmt.Printf("%d vertices, %d faces\n", len(mesh.Vertices), len(mesh.Faces))
+
+		if indexOBJPath != "" {
+			if err := writeOBJ(indexOBJPath, mesh); err != nil {
+				fmt.Println(err.Error())
+			}
+		}
+	},
+}
+
+// writeOBJ emits mesh as a minimal Wavefront OBJ: vertex positions
+// followed by faces, using OBJ's 1-based vertex indices.
+func writeOBJ(path string, mesh *stl.IndexedMesh) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, v := range mesh.Vertices {
+		if _, err := fmt.Fprintf(f, "v %g %g %g\n", v[0], v[1], v[2]); err != nil {
+			return err
+		}
+	}
+	for _, face := range mesh.Faces {
+		if _, err := fmt.Fprintf(f, "f %d %d %d\n", face[0]+1, face[1]+1, face[2]+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+
+	// Here you will define your flags and configuration settings.
+	indexCmd.Flags().Float32Var(&indexEpsilon, "epsilon", 1e-5, "distance under which vertices are merged")
+	indexCmd.Flags().StringVar(&indexOBJPath, "obj", "", "path to write a simple OBJ file to")
+}
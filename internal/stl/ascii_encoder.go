@@ -0,0 +1,51 @@
+package stl
+
+import (
+	"fmt"
+	"io"
+)
+
+// ASCIIEncoder writes triangles as an ASCII STL solid.
+type ASCIIEncoder struct {
+	w   io.Writer
+	err error
+}
+
+// NewASCIIEncoder returns an ASCIIEncoder that writes an ASCII STL solid
+// named name to w. The caller must call Close when done to write the
+// closing "endsolid" line.
+func NewASCIIEncoder(w io.Writer, name string) *ASCIIEncoder {
+	e := &ASCIIEncoder{w: w}
+	_, e.err = io.WriteString(w, "solid "+name+"\n")
+	return e
+}
+
+// Write appends a triangle to the solid. If t.Normal is the zero vector,
+// it's recomputed from the vertices using the right-hand rule.
+func (e *ASCIIEncoder) Write(t Triangle) error {
+	if e.err != nil {
+		return e.err
+	}
+	if t.Normal == ([3]float32{}) {
+		t.Normal = computeNormal(t.Vertices)
+	}
+
+	_, e.err = fmt.Fprintf(e.w,
+		"facet normal %g %g %g\nouter loop\nvertex %g %g %g\nvertex %g %g %g\nvertex %g %g %g\nendloop\nendfacet\n",
+		t.Normal[0], t.Normal[1], t.Normal[2],
+		t.Vertices[0][0], t.Vertices[0][1], t.Vertices[0][2],
+		t.Vertices[1][0], t.Vertices[1][1], t.Vertices[1][2],
+		t.Vertices[2][0], t.Vertices[2][1], t.Vertices[2][2],
+	)
+	return e.err
+}
+
+// Close writes the closing "endsolid" line and returns the first error
+// encountered while writing.
+func (e *ASCIIEncoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	_, e.err = io.WriteString(e.w, "endsolid\n")
+	return e.err
+}
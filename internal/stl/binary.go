@@ -0,0 +1,101 @@
+package stl
+
+// This file defines a decoder for the binary STL format: an 80-byte header,
+// a little-endian uint32 triangle count, then 50 bytes per facet (12
+// float32s followed by a uint16 attribute byte count).
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+const (
+	binaryHeaderSize = 80
+	binaryFacetSize  = 50
+
+	// maxPreallocTriangles caps how many triangles decodeBinary will
+	// pre-allocate for from an untrusted header count. A crafted count
+	// near math.MaxUint32 must not translate into a multi-gigabyte
+	// allocation before a single facet has actually been read; append
+	// still grows the slice past this for a genuinely large, valid file.
+	maxPreallocTriangles = 1 << 20
+)
+
+// ErrBinaryLengthMismatch is returned when a binary STL's file size doesn't
+// match 84 + 50*count, the size implied by its own header.
+var ErrBinaryLengthMismatch = errors.New("binary STL: file length does not match header triangle count")
+
+// sizer is implemented by *os.File; it's used to validate that a binary
+// STL's declared triangle count agrees with the actual file length.
+type sizer interface {
+	Stat() (os.FileInfo, error)
+}
+
+func decodeBinary(r io.Reader, original io.Reader) (*Solid, error) {
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("binary STL: reading header: %w", err)
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("binary STL: reading triangle count: %w", err)
+	}
+
+	if s, ok := original.(sizer); ok {
+		if err := validateBinaryLength(s, count); err != nil {
+			return nil, err
+		}
+	}
+
+	prealloc := count
+	if prealloc > maxPreallocTriangles {
+		prealloc = maxPreallocTriangles
+	}
+	triangles := make([]Triangle, 0, prealloc)
+	facet := make([]byte, binaryFacetSize)
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(r, facet); err != nil {
+			return nil, fmt.Errorf("binary STL: reading facet %d: %w", i, err)
+		}
+		triangles = append(triangles, decodeFacet(facet))
+	}
+
+	return &Solid{Name: extractASCIIString(header), Triangles: triangles}, nil
+}
+
+func decodeFacet(b []byte) Triangle {
+	readVec := func(off int) [3]float32 {
+		var v [3]float32
+		for i := 0; i < 3; i++ {
+			bits := binary.LittleEndian.Uint32(b[off+i*4 : off+i*4+4])
+			v[i] = math.Float32frombits(bits)
+		}
+		return v
+	}
+
+	var t Triangle
+	t.Normal = readVec(0)
+	t.Vertices[0] = readVec(12)
+	t.Vertices[1] = readVec(24)
+	t.Vertices[2] = readVec(36)
+	t.Attr = binary.LittleEndian.Uint16(b[48:50])
+	return t
+}
+
+func validateBinaryLength(s sizer, count uint32) error {
+	info, err := s.Stat()
+	if err != nil {
+		return nil
+	}
+
+	want := int64(binaryHeaderSize+4) + int64(count)*binaryFacetSize
+	if info.Size() != want {
+		return fmt.Errorf("%w: want %d bytes, have %d", ErrBinaryLengthMismatch, want, info.Size())
+	}
+	return nil
+}
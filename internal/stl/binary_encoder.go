@@ -0,0 +1,111 @@
+package stl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrBinaryEncoderNeedsSeeker is returned by BinaryEncoder.Close when w
+// doesn't implement io.Seeker and the triangle count wasn't supplied up
+// front via NewBinaryEncoderN.
+var ErrBinaryEncoderNeedsSeeker = errors.New("binary STL: w must implement io.Seeker to patch the triangle count on Close; use NewBinaryEncoderN instead")
+
+// BinaryEncoder writes triangles as a binary STL solid.
+type BinaryEncoder struct {
+	w             io.Writer
+	count         uint32
+	declaredCount uint32
+	knownCount    bool
+	err           error
+}
+
+// NewBinaryEncoder returns a BinaryEncoder that writes a binary STL solid
+// to w, using header as the 80-byte header. The triangle count is written
+// as zero and patched in place once Close knows the final count, so w
+// must implement io.Seeker; if it doesn't, use NewBinaryEncoderN with a
+// known count instead.
+func NewBinaryEncoder(w io.Writer, header [80]byte) *BinaryEncoder {
+	e := &BinaryEncoder{w: w}
+	e.writeHeader(header, 0)
+	return e
+}
+
+// NewBinaryEncoderN returns a BinaryEncoder like NewBinaryEncoder, but
+// writes the final triangle count immediately since it's already known,
+// so w need not implement io.Seeker.
+func NewBinaryEncoderN(w io.Writer, header [80]byte, count uint32) *BinaryEncoder {
+	e := &BinaryEncoder{w: w, declaredCount: count, knownCount: true}
+	e.writeHeader(header, count)
+	return e
+}
+
+func (e *BinaryEncoder) writeHeader(header [80]byte, count uint32) {
+	if _, err := e.w.Write(header[:]); err != nil {
+		e.err = err
+		return
+	}
+	var countBuf [4]byte
+	binary.LittleEndian.PutUint32(countBuf[:], count)
+	_, e.err = e.w.Write(countBuf[:])
+}
+
+// Write appends a triangle to the solid. If t.Normal is the zero vector,
+// it's recomputed from the vertices using the right-hand rule.
+func (e *BinaryEncoder) Write(t Triangle) error {
+	if e.err != nil {
+		return e.err
+	}
+	if t.Normal == ([3]float32{}) {
+		t.Normal = computeNormal(t.Vertices)
+	}
+
+	var facet [binaryFacetSize]byte
+	putVec := func(off int, v [3]float32) {
+		for i := 0; i < 3; i++ {
+			binary.LittleEndian.PutUint32(facet[off+i*4:off+i*4+4], math.Float32bits(v[i]))
+		}
+	}
+	putVec(0, t.Normal)
+	putVec(12, t.Vertices[0])
+	putVec(24, t.Vertices[1])
+	putVec(36, t.Vertices[2])
+	binary.LittleEndian.PutUint16(facet[48:50], t.Attr)
+
+	if _, err := e.w.Write(facet[:]); err != nil {
+		e.err = err
+		return err
+	}
+	e.count++
+	return nil
+}
+
+// Close patches the triangle count header if it wasn't supplied up front,
+// and returns the first error encountered while writing.
+func (e *BinaryEncoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.knownCount {
+		if e.count != e.declaredCount {
+			return fmt.Errorf("binary STL: wrote %d triangles, header declared %d", e.count, e.declaredCount)
+		}
+		return nil
+	}
+
+	seeker, ok := e.w.(io.Seeker)
+	if !ok {
+		return ErrBinaryEncoderNeedsSeeker
+	}
+	if _, err := seeker.Seek(binaryHeaderSize, io.SeekStart); err != nil {
+		return err
+	}
+
+	var countBuf [4]byte
+	binary.LittleEndian.PutUint32(countBuf[:], e.count)
+	_, err := e.w.Write(countBuf[:])
+	return err
+}
@@ -4,18 +4,27 @@ import (
 	"stl-parser/internal/file"
 )
 
-var name string
-var triangles []Triangle
-
+// CountTriangles reports the solid's name and how many triangles it
+// contains. It streams the file through a Decoder, so it uses O(1) memory
+// regardless of mesh size.
 func CountTriangles(filepath string) (string, int, error) {
 	scanner, close, err := file.Reader(filepath)
 	if err != nil {
 		return "", -1, err
 	}
 	defer close()
-	parse := newParser(scanner)
 
-	parse.Parse()
+	d := newDecoder(scanner)
+	name := d.Header()
+
+	count := 0
+	for {
+		_, err := d.Next()
+		if err != nil {
+			break
+		}
+		count++
+	}
 
-	return name, len(triangles), nil
+	return name, count, nil
 }
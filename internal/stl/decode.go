@@ -0,0 +1,40 @@
+package stl
+
+// This file defines Decode, the top-level entry point that autodetects
+// ASCII vs binary STL and dispatches to the matching decoder.
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// peekWindow is how many leading bytes Decode inspects to distinguish ASCII
+// from binary STL.
+const peekWindow = 512
+
+// Decode reads a Solid from r, automatically detecting whether the stream is
+// ASCII or binary STL. Some binary STL files are misleadingly prefixed with
+// "solid ", so Decode falls back to binary whenever any byte in the first
+// peekWindow bytes isn't plain ASCII.
+func Decode(r io.Reader) (*Solid, error) {
+	br := bufio.NewReaderSize(r, peekWindow)
+	peek, _ := br.Peek(peekWindow)
+
+	if looksLikeASCII(peek) {
+		return ReadAll(br)
+	}
+	return decodeBinary(br, r)
+}
+
+func looksLikeASCII(peek []byte) bool {
+	if !bytes.HasPrefix(peek, expectedASCIIHeaderPrefix) {
+		return false
+	}
+	for _, b := range peek {
+		if b >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package stl
+
+// This file defines Decoder, the reentrant, streaming ASCII STL reader.
+// A Decoder owns its own state, so multiple decoders can run concurrently
+// and callers can read triangles one at a time without holding the whole
+// solid in memory.
+
+import (
+	"bufio"
+	"io"
+)
+
+// Decoder reads an ASCII STL solid one triangle at a time.
+type Decoder struct {
+	p          *parser
+	headerRead bool
+	done       bool
+}
+
+// DecoderOption configures a Decoder returned by NewDecoder.
+type DecoderOption func(*Decoder)
+
+// WithErrorHandler calls h with the line number and error for every
+// recoverable syntax error the Decoder encounters, in addition to joining
+// it into the parser's internal error state, which the Decoder does not
+// expose.
+func WithErrorHandler(h func(line int, err error)) DecoderOption {
+	return func(d *Decoder) { d.p.onError = h }
+}
+
+// WithMaxErrors makes Next return a fatal error once more than n
+// recoverable errors have been seen, instead of tolerating them all. An n
+// of zero or less sets no limit.
+func WithMaxErrors(n int) DecoderOption {
+	return func(d *Decoder) { d.p.maxErrors = n }
+}
+
+// WithStrict makes Next return a fatal error as soon as the first
+// recoverable error is seen, rather than skipping past it.
+func WithStrict() DecoderOption {
+	return func(d *Decoder) { d.p.strict = true }
+}
+
+// NewDecoder returns a Decoder that reads an ASCII STL solid from r.
+func NewDecoder(r io.Reader, opts ...DecoderOption) *Decoder {
+	lineScanner := bufio.NewScanner(r)
+	lineScanner.Split(bufio.ScanLines)
+	d := newDecoder(lineScanner)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func newDecoder(lineScanner *bufio.Scanner) *Decoder {
+	return &Decoder{p: newParser(lineScanner)}
+}
+
+// Header returns the solid's name, read from the "solid <name>" line.
+func (d *Decoder) Header() string {
+	d.readHeader()
+	return d.p.name
+}
+
+func (d *Decoder) readHeader() {
+	if d.headerRead {
+		return
+	}
+	d.headerRead = true
+	if d.p.eof {
+		d.p.HeaderError = true
+		d.p.addError(ErrEOF)
+		return
+	}
+	d.p.HeaderError = !d.p.parseASCIIHeaderLine()
+}
+
+// Next returns the next triangle in the solid, or io.EOF once "endsolid"
+// (or the end of the stream) is reached. If the Decoder was built with
+// WithStrict or WithMaxErrors and the corresponding threshold is hit, Next
+// returns that error instead of io.EOF and every subsequent call keeps
+// returning it.
+func (d *Decoder) Next() (Triangle, error) {
+	d.readHeader()
+	if d.p.fatalErr != nil {
+		d.done = true
+		return Triangle{}, d.p.fatalErr
+	}
+	if d.done || d.p.HeaderError {
+		d.done = true
+		return Triangle{}, io.EOF
+	}
+
+	for !d.p.eof && !d.p.isCurrentTokenIdent(idEndsolid) {
+		if !d.p.isCurrentTokenIdent(idFacet) {
+			d.p.addError(ErrInvalidExpectedToken)
+			if d.p.fatalErr != nil {
+				d.done = true
+				return Triangle{}, d.p.fatalErr
+			}
+			switch d.p.skipToToken(idFacet | idEndsolid) {
+			case idEndsolid, idNone:
+				d.done = true
+				return Triangle{}, io.EOF
+			}
+			continue
+		}
+
+		var t Triangle
+		if d.p.parseFacet(&t) {
+			return t, nil
+		}
+		d.p.TrianglesSkipped = true
+		if d.p.fatalErr != nil {
+			d.done = true
+			return Triangle{}, d.p.fatalErr
+		}
+		d.p.skipToToken(idFacet | idEndsolid)
+	}
+
+	d.done = true
+	if d.p.fatalErr != nil {
+		return Triangle{}, d.p.fatalErr
+	}
+	if !d.p.TrianglesSkipped {
+		d.p.consumeToken(idEndsolid)
+		if d.p.fatalErr != nil {
+			return Triangle{}, d.p.fatalErr
+		}
+	}
+	return Triangle{}, io.EOF
+}
+
+// ReadAll decodes every triangle in r into a Solid.
+func ReadAll(r io.Reader) (*Solid, error) {
+	d := NewDecoder(r)
+	solid := &Solid{Name: d.Header()}
+	for {
+		t, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return solid, err
+		}
+		solid.Triangles = append(solid.Triangles, t)
+	}
+	return solid, nil
+}
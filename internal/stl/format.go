@@ -0,0 +1,47 @@
+package stl
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format identifies an STL encoding, for use with Convert.
+type Format int
+
+const (
+	FormatASCII Format = iota
+	FormatBinary
+)
+
+// Convert decodes src, autodetecting ASCII vs binary STL, and re-encodes
+// it into dst in the requested format. It's a cheap way to convert an STL
+// file without hand-rolling a decode/encode loop.
+func Convert(src io.Reader, dst io.Writer, format Format) error {
+	solid, err := Decode(src)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatASCII:
+		enc := NewASCIIEncoder(dst, solid.Name)
+		for _, t := range solid.Triangles {
+			if err := enc.Write(t); err != nil {
+				return err
+			}
+		}
+		return enc.Close()
+	case FormatBinary:
+		var header [80]byte
+		copy(header[:], solid.Name)
+		enc := NewBinaryEncoderN(dst, header, uint32(len(solid.Triangles)))
+		for _, t := range solid.Triangles {
+			if err := enc.Write(t); err != nil {
+				return err
+			}
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("stl: unknown format %d", format)
+	}
+}
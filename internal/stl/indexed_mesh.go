@@ -0,0 +1,118 @@
+package stl
+
+import (
+	"io"
+	"math"
+)
+
+// IndexedMesh is a deduplicated triangle mesh: shared vertices appear once
+// and Faces reference them by index, the representation most downstream
+// tools (OpenGL, glTF, OBJ) expect instead of STL's per-face vertex soup.
+type IndexedMesh struct {
+	Vertices [][3]float32
+	Normals  [][3]float32
+	Faces    [][3]uint32
+}
+
+// BuildIndexed reads every triangle from d (ASCII STL only; use Decode
+// plus BuildIndexedSolid for binary-or-ASCII input) and welds vertices
+// within epsilon of each other into a single indexed-mesh vertex.
+func BuildIndexed(d *Decoder, epsilon float32) (*IndexedMesh, error) {
+	return buildIndexed(epsilon, d.Next)
+}
+
+// BuildIndexedSolid welds the triangles already decoded into s — from
+// Decode, which autodetects ASCII vs binary STL — into an IndexedMesh.
+func BuildIndexedSolid(s *Solid, epsilon float32) *IndexedMesh {
+	i := 0
+	m, _ := buildIndexed(epsilon, func() (Triangle, error) {
+		if i >= len(s.Triangles) {
+			return Triangle{}, io.EOF
+		}
+		t := s.Triangles[i]
+		i++
+		return t, nil
+	})
+	return m
+}
+
+// buildIndexed welds vertices within epsilon of each other into a single
+// indexed-mesh vertex, pulling triangles from next until it returns
+// io.EOF. Vertices are bucketed by their quantized coordinates, and each
+// lookup checks the surrounding 3x3x3 block of buckets, so only nearby
+// candidates are compared while candidates straddling a bucket boundary
+// still weld.
+func buildIndexed(epsilon float32, next func() (Triangle, error)) (*IndexedMesh, error) {
+	m := &IndexedMesh{}
+	buckets := make(map[[3]int32][]uint32)
+
+	addVertex := func(v [3]float32) uint32 {
+		if idx, ok := findNearVertex(m.Vertices, buckets, v, epsilon); ok {
+			return idx
+		}
+		idx := uint32(len(m.Vertices))
+		m.Vertices = append(m.Vertices, v)
+		key := quantize(v, epsilon)
+		buckets[key] = append(buckets[key], idx)
+		return idx
+	}
+
+	for {
+		t, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var face [3]uint32
+		for i, v := range t.Vertices {
+			face[i] = addVertex(v)
+		}
+		m.Faces = append(m.Faces, face)
+		m.Normals = append(m.Normals, t.Normal)
+	}
+
+	return m, nil
+}
+
+func quantize(v [3]float32, epsilon float32) [3]int32 {
+	return [3]int32{
+		int32(math.Round(float64(v[0] / epsilon))),
+		int32(math.Round(float64(v[1] / epsilon))),
+		int32(math.Round(float64(v[2] / epsilon))),
+	}
+}
+
+func nearEqual(a, b [3]float32, epsilon float32) bool {
+	for i := 0; i < 3; i++ {
+		if float32(math.Abs(float64(a[i]-b[i]))) > epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// findNearVertex looks for an existing vertex within epsilon of v. A
+// vertex's quantized cell alone isn't enough: two vertices less than
+// epsilon apart can still quantize to different, adjacent cells when v is
+// near a cell boundary, so every cell in the surrounding 3x3x3 block must
+// be checked too.
+func findNearVertex(vertices [][3]float32, buckets map[[3]int32][]uint32, v [3]float32, epsilon float32) (uint32, bool) {
+	center := quantize(v, epsilon)
+	var cell [3]int32
+	for dx := int32(-1); dx <= 1; dx++ {
+		for dy := int32(-1); dy <= 1; dy++ {
+			for dz := int32(-1); dz <= 1; dz++ {
+				cell = [3]int32{center[0] + dx, center[1] + dy, center[2] + dz}
+				for _, idx := range buckets[cell] {
+					if nearEqual(vertices[idx], v, epsilon) {
+						return idx, true
+					}
+				}
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,26 @@
+package stl
+
+import "math"
+
+// computeNormal derives a unit facet normal for v using the right-hand
+// rule: the cross product of the edges v1-v0 and v2-v0, wound the same way
+// STL vertices are listed.
+func computeNormal(v [3][3]float32) [3]float32 {
+	var e1, e2 [3]float32
+	for i := 0; i < 3; i++ {
+		e1[i] = v[1][i] - v[0][i]
+		e2[i] = v[2][i] - v[0][i]
+	}
+
+	n := [3]float32{
+		e1[1]*e2[2] - e1[2]*e2[1],
+		e1[2]*e2[0] - e1[0]*e2[2],
+		e1[0]*e2[1] - e1[1]*e2[0],
+	}
+
+	length := float32(math.Sqrt(float64(n[0]*n[0] + n[1]*n[1] + n[2]*n[2])))
+	if length == 0 {
+		return n
+	}
+	return [3]float32{n[0] / length, n[1] / length, n[2] / length}
+}
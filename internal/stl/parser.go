@@ -73,43 +73,38 @@ type parser struct {
 	eof              bool
 	lineScanner      *bufio.Scanner
 	wordScanner      *bufio.Scanner
+	name             string
 	HeaderError      bool
 	TrianglesSkipped bool
 	ErrorText        string
-}
 
-func (p *parser) addError(msg error) {
-	p.errors = errors.Join(fmt.Errorf("%d: %w", p.line, msg))
+	onError    func(line int, err error)
+	maxErrors  int
+	strict     bool
+	errorCount int
+	fatalErr   error
 }
 
-func (p *parser) Parse() bool {
-	if p.eof {
-		p.HeaderError = true
-		p.addError(ErrEOF)
+// addError records err against the current line, joining it with any
+// errors already collected, reports it to onError if one is set, and marks
+// the parser as fatally errored once strict or maxErrors says to stop.
+func (p *parser) addError(msg error) {
+	err := fmt.Errorf("%d: %w", p.line, msg)
+	if p.errors != nil {
+		p.errors = errors.Join(p.errors, err)
 	} else {
-		p.HeaderError = !p.parseASCIIHeaderLine()
-	TriangleLoop:
-		for !p.eof && !p.isCurrentTokenIdent(idEndsolid) {
-			if !p.isCurrentTokenIdent(idFacet) {
-				p.addError(ErrInvalidExpectedToken)
-				switch p.skipToToken(idFacet | idEndsolid) {
-				case idEndsolid, idNone:
-					break TriangleLoop
-				}
-			}
+		p.errors = err
+	}
+	p.errorCount++
 
-			var t Triangle
-			if p.parseFacet(&t) {
-				triangles = append(triangles, t)
-			} else {
-				p.TrianglesSkipped = true
-				p.skipToToken(idFacet | idEndsolid)
-			}
-		}
+	if p.onError != nil {
+		p.onError(p.line, err)
 	}
 
-	success := !p.HeaderError && !p.TrianglesSkipped && p.consumeToken(idEndsolid)
-	return success
+	if p.fatalErr == nil && (p.strict || (p.maxErrors > 0 && p.errorCount > p.maxErrors)) {
+		p.fatalErr = err
+		p.eof = true
+	}
 }
 
 func (p *parser) parseASCIIHeaderLine() bool {
@@ -122,7 +117,7 @@ func (p *parser) parseASCIIHeaderLine() bool {
 			p.addError(ErrInvalidSintax)
 			success = false
 		} else {
-			name = extractASCIIString(p.currentLine[len(expectedASCIIHeaderPrefix):])
+			p.name = extractASCIIString(p.currentLine[len(expectedASCIIHeaderPrefix):])
 			success = true
 		}
 	}
@@ -0,0 +1,126 @@
+package stl
+
+// RepairOptions selects which fixes Repair applies to a mesh.
+type RepairOptions struct {
+	// RemoveDegenerates drops zero-area / collinear triangles.
+	RemoveDegenerates bool
+
+	// FixWinding flips faces so every edge is walked in opposite
+	// directions by its two adjacent faces, starting from a seed face
+	// and propagating via breadth-first search over shared edges.
+	FixWinding bool
+
+	// WeldEpsilon welds vertices closer together than this distance.
+	// Zero disables welding.
+	WeldEpsilon float32
+}
+
+// Repair returns a new IndexedMesh with the requested fixes applied, or m
+// itself if no fix is selected. m is left unmodified.
+func Repair(m *IndexedMesh, opts RepairOptions) *IndexedMesh {
+	out := m
+	if opts.RemoveDegenerates {
+		out = removeDegenerates(out)
+	}
+	if opts.FixWinding {
+		out = fixWinding(out)
+	}
+	if opts.WeldEpsilon > 0 {
+		out = weldVertices(out, opts.WeldEpsilon)
+	}
+	return out
+}
+
+func removeDegenerates(m *IndexedMesh) *IndexedMesh {
+	out := &IndexedMesh{Vertices: m.Vertices}
+	for i, f := range m.Faces {
+		if isDegenerate(m, f) {
+			continue
+		}
+		out.Faces = append(out.Faces, f)
+		if i < len(m.Normals) {
+			out.Normals = append(out.Normals, m.Normals[i])
+		}
+	}
+	return out
+}
+
+func flipFace(f [3]uint32) [3]uint32 {
+	return [3]uint32{f[0], f[2], f[1]}
+}
+
+// fixWinding makes neighboring faces agree on winding direction. Starting
+// from a seed face in each disconnected shell, it walks the face
+// adjacency graph breadth-first, flipping any neighbor whose shared edge
+// is traversed in the same direction as its already-fixed neighbor.
+func fixWinding(m *IndexedMesh) *IndexedMesh {
+	faces := make([][3]uint32, len(m.Faces))
+	copy(faces, m.Faces)
+
+	adjacency := make(map[edgeKey][]int)
+	for i, f := range faces {
+		for _, p := range facePairs(f) {
+			key := sortedEdge(p[0], p[1])
+			adjacency[key] = append(adjacency[key], i)
+		}
+	}
+
+	visited := make([]bool, len(faces))
+	for seed := range faces {
+		if visited[seed] {
+			continue
+		}
+		visited[seed] = true
+		queue := []int{seed}
+
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			for _, p := range facePairs(faces[cur]) {
+				key := sortedEdge(p[0], p[1])
+				curReversed := edgeDirectionIn(faces[cur], key)
+
+				for _, neighbor := range adjacency[key] {
+					if neighbor == cur || visited[neighbor] {
+						continue
+					}
+					if edgeDirectionIn(faces[neighbor], key) == curReversed {
+						faces[neighbor] = flipFace(faces[neighbor])
+					}
+					visited[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	return &IndexedMesh{Vertices: m.Vertices, Normals: m.Normals, Faces: faces}
+}
+
+// weldVertices merges vertices within epsilon of each other, the same
+// bucketed-neighbor-search scheme BuildIndexed uses.
+func weldVertices(m *IndexedMesh, epsilon float32) *IndexedMesh {
+	remap := make([]uint32, len(m.Vertices))
+	var vertices [][3]float32
+	buckets := make(map[[3]int32][]uint32)
+
+	for i, v := range m.Vertices {
+		if idx, ok := findNearVertex(vertices, buckets, v, epsilon); ok {
+			remap[i] = idx
+			continue
+		}
+		idx := uint32(len(vertices))
+		vertices = append(vertices, v)
+		key := quantize(v, epsilon)
+		buckets[key] = append(buckets[key], idx)
+		remap[i] = idx
+	}
+
+	faces := make([][3]uint32, len(m.Faces))
+	for i, f := range m.Faces {
+		faces[i] = [3]uint32{remap[f[0]], remap[f[1]], remap[f[2]]}
+	}
+
+	return &IndexedMesh{Vertices: vertices, Normals: m.Normals, Faces: faces}
+}
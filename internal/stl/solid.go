@@ -0,0 +1,11 @@
+package stl
+
+// Solid is a parsed STL model: its header/name and the triangles it contains.
+type Solid struct {
+	// Name is the solid's name, taken from the ASCII "solid <name>" header
+	// or the binary 80-byte header.
+	Name string
+
+	// Triangles are the facets that make up the solid.
+	Triangles []Triangle
+}
@@ -0,0 +1,120 @@
+package stl
+
+// ValidationReport summarizes the defects Validate found in an
+// IndexedMesh: degenerate triangles, non-manifold and inconsistently
+// wound edges, duplicated faces, and vertices no face references.
+type ValidationReport struct {
+	DegenerateFaces      []int
+	NonManifoldEdges     int
+	InconsistentEdges    int
+	DuplicateFaces       []int
+	UnreferencedVertices []uint32
+}
+
+// edgeKey is an undirected edge, its two vertex indices sorted ascending
+// so both faces sharing an edge hash to the same key.
+type edgeKey [2]uint32
+
+func sortedEdge(a, b uint32) edgeKey {
+	if a < b {
+		return edgeKey{a, b}
+	}
+	return edgeKey{b, a}
+}
+
+func facePairs(f [3]uint32) [3][2]uint32 {
+	return [3][2]uint32{{f[0], f[1]}, {f[1], f[2]}, {f[2], f[0]}}
+}
+
+// edgeDirectionIn reports whether face traverses the undirected edge key
+// in reverse (key[1] -> key[0]) rather than forward (key[0] -> key[1]).
+func edgeDirectionIn(f [3]uint32, key edgeKey) bool {
+	for _, p := range facePairs(f) {
+		if sortedEdge(p[0], p[1]) == key {
+			return p[0] != key[0]
+		}
+	}
+	return false
+}
+
+// buildEdgeMap maps every undirected edge in m to the faces that share it.
+func buildEdgeMap(m *IndexedMesh) map[edgeKey][]int {
+	edges := make(map[edgeKey][]int)
+	for i, f := range m.Faces {
+		for _, p := range facePairs(f) {
+			key := sortedEdge(p[0], p[1])
+			edges[key] = append(edges[key], i)
+		}
+	}
+	return edges
+}
+
+func canonicalFace(f [3]uint32) [3]uint32 {
+	a, b, c := f[0], f[1], f[2]
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b, c = c, b
+	}
+	if a > b {
+		a, b = b, a
+	}
+	return [3]uint32{a, b, c}
+}
+
+func isDegenerate(m *IndexedMesh, f [3]uint32) bool {
+	if f[0] == f[1] || f[1] == f[2] || f[0] == f[2] {
+		return true
+	}
+	v := [3][3]float32{m.Vertices[f[0]], m.Vertices[f[1]], m.Vertices[f[2]]}
+	return computeNormal(v) == [3]float32{}
+}
+
+// Validate inspects m for the defects that most often break downstream
+// 3D-printing and rendering pipelines: degenerate (zero-area or collinear)
+// triangles, non-manifold edges (shared by other than 2 faces),
+// inconsistently oriented neighbors (a shared edge walked the same
+// direction by both of its faces), duplicated faces, and vertices that no
+// face references.
+func Validate(m *IndexedMesh) *ValidationReport {
+	r := &ValidationReport{}
+
+	referenced := make([]bool, len(m.Vertices))
+	seen := make(map[[3]uint32]int)
+
+	for i, f := range m.Faces {
+		for _, idx := range f {
+			referenced[idx] = true
+		}
+
+		if isDegenerate(m, f) {
+			r.DegenerateFaces = append(r.DegenerateFaces, i)
+		}
+
+		key := canonicalFace(f)
+		if _, ok := seen[key]; ok {
+			r.DuplicateFaces = append(r.DuplicateFaces, i)
+		} else {
+			seen[key] = i
+		}
+	}
+
+	for key, faceIdxs := range buildEdgeMap(m) {
+		if len(faceIdxs) != 2 {
+			r.NonManifoldEdges++
+			continue
+		}
+		if edgeDirectionIn(m.Faces[faceIdxs[0]], key) == edgeDirectionIn(m.Faces[faceIdxs[1]], key) {
+			r.InconsistentEdges++
+		}
+	}
+
+	for idx, ref := range referenced {
+		if !ref {
+			r.UnreferencedVertices = append(r.UnreferencedVertices, uint32(idx))
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,145 @@
+package stl
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertices [][3]float32
+		faces    [][3]uint32
+		check    func(t *testing.T, r *ValidationReport)
+	}{
+		{
+			name: "degenerate face",
+			vertices: [][3]float32{
+				{0, 0, 0},
+				{1, 0, 0},
+				{0, 1, 0},
+			},
+			faces: [][3]uint32{{0, 0, 2}},
+			check: func(t *testing.T, r *ValidationReport) {
+				if len(r.DegenerateFaces) != 1 || r.DegenerateFaces[0] != 0 {
+					t.Errorf("DegenerateFaces = %v, want [0]", r.DegenerateFaces)
+				}
+			},
+		},
+		{
+			name: "non-manifold boundary edges",
+			vertices: [][3]float32{
+				{0, 0, 0},
+				{1, 0, 0},
+				{0, 1, 0},
+			},
+			faces: [][3]uint32{{0, 1, 2}},
+			check: func(t *testing.T, r *ValidationReport) {
+				// A single, unshared triangle has 3 boundary edges, each
+				// touched by only 1 face instead of 2.
+				if r.NonManifoldEdges != 3 {
+					t.Errorf("NonManifoldEdges = %d, want 3", r.NonManifoldEdges)
+				}
+			},
+		},
+		{
+			name: "duplicate faces",
+			vertices: [][3]float32{
+				{0, 0, 0},
+				{1, 0, 0},
+				{0, 1, 0},
+			},
+			faces: [][3]uint32{{0, 1, 2}, {2, 1, 0}},
+			check: func(t *testing.T, r *ValidationReport) {
+				if len(r.DuplicateFaces) != 1 {
+					t.Errorf("DuplicateFaces = %v, want 1 entry", r.DuplicateFaces)
+				}
+			},
+		},
+		{
+			name: "inconsistently wound neighbor",
+			vertices: [][3]float32{
+				{0, 0, 0},
+				{1, 0, 0},
+				{1, 1, 0},
+				{0, 1, 0},
+			},
+			// A quad split into two triangles sharing edge (0,2), with
+			// the second triangle wound so both faces traverse that edge
+			// in the same direction.
+			faces: [][3]uint32{{0, 1, 2}, {2, 0, 3}},
+			check: func(t *testing.T, r *ValidationReport) {
+				if r.InconsistentEdges != 1 {
+					t.Errorf("InconsistentEdges = %d, want 1", r.InconsistentEdges)
+				}
+			},
+		},
+		{
+			name: "unreferenced vertex",
+			vertices: [][3]float32{
+				{0, 0, 0},
+				{1, 0, 0},
+				{0, 1, 0},
+				{5, 5, 5},
+			},
+			faces: [][3]uint32{{0, 1, 2}},
+			check: func(t *testing.T, r *ValidationReport) {
+				if len(r.UnreferencedVertices) != 1 || r.UnreferencedVertices[0] != 3 {
+					t.Errorf("UnreferencedVertices = %v, want [3]", r.UnreferencedVertices)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &IndexedMesh{Vertices: tt.vertices, Faces: tt.faces}
+			tt.check(t, Validate(m))
+		})
+	}
+}
+
+func TestFixWinding(t *testing.T) {
+	m := &IndexedMesh{
+		Vertices: [][3]float32{
+			{0, 0, 0},
+			{1, 0, 0},
+			{1, 1, 0},
+			{0, 1, 0},
+		},
+		// Same bad-winding quad as in TestValidate: both triangles
+		// traverse their shared edge (0,2) in the same direction.
+		Faces: [][3]uint32{{0, 1, 2}, {2, 0, 3}},
+	}
+
+	if got := Validate(m).InconsistentEdges; got != 1 {
+		t.Fatalf("input InconsistentEdges = %d, want 1", got)
+	}
+
+	fixed := Repair(m, RepairOptions{FixWinding: true})
+
+	if got := Validate(fixed).InconsistentEdges; got != 0 {
+		t.Errorf("after FixWinding, InconsistentEdges = %d, want 0", got)
+	}
+}
+
+func TestRepairRemoveDegenerates(t *testing.T) {
+	m := &IndexedMesh{
+		Vertices: [][3]float32{
+			{0, 0, 0},
+			{1, 0, 0},
+			{0, 1, 0},
+		},
+		Faces: [][3]uint32{
+			{0, 0, 2}, // degenerate: repeated vertex
+			{0, 1, 2}, // good
+		},
+		Normals: [][3]float32{{0, 0, 0}, {0, 0, 1}},
+	}
+
+	repaired := Repair(m, RepairOptions{RemoveDegenerates: true})
+
+	if len(repaired.Faces) != 1 {
+		t.Fatalf("len(Faces) = %d, want 1", len(repaired.Faces))
+	}
+	if repaired.Faces[0] != m.Faces[1] {
+		t.Errorf("surviving face = %v, want %v", repaired.Faces[0], m.Faces[1])
+	}
+}